@@ -0,0 +1,63 @@
+package posterize
+
+import (
+	"encoding/binary"
+	"image"
+	"io"
+)
+
+// encodeBMP writes p as an 8-bit-per-pixel indexed BMP (BITMAPINFOHEADER,
+// uncompressed). golang.org/x/image/bmp only implements Decode, so this
+// package carries its own encoder for the one case it needs: a palette
+// of at most 256 colors, written out at one byte per pixel.
+func encodeBMP(w io.Writer, p *image.Paletted) error {
+	bounds := p.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	const (
+		fileHeaderSize = 14
+		infoHeaderSize = 40
+	)
+	paletteSize := len(p.Palette) * 4
+	rowSize := (width + 3) &^ 3 // BMP rows are padded to a 4-byte boundary
+	pixelDataOffset := fileHeaderSize + infoHeaderSize + paletteSize
+	fileSize := pixelDataOffset + rowSize*height
+
+	buf := make([]byte, fileSize)
+
+	// File header.
+	buf[0], buf[1] = 'B', 'M'
+	binary.LittleEndian.PutUint32(buf[2:], uint32(fileSize))
+	binary.LittleEndian.PutUint32(buf[10:], uint32(pixelDataOffset))
+
+	// BITMAPINFOHEADER.
+	h := buf[fileHeaderSize:]
+	binary.LittleEndian.PutUint32(h[0:], infoHeaderSize)
+	binary.LittleEndian.PutUint32(h[4:], uint32(width))
+	binary.LittleEndian.PutUint32(h[8:], uint32(height))
+	binary.LittleEndian.PutUint16(h[12:], 1) // color planes
+	binary.LittleEndian.PutUint16(h[14:], 8) // bits per pixel
+	binary.LittleEndian.PutUint32(h[20:], uint32(rowSize*height))
+	binary.LittleEndian.PutUint32(h[32:], uint32(len(p.Palette)))
+	binary.LittleEndian.PutUint32(h[36:], uint32(len(p.Palette)))
+
+	// Palette, as BGRX quads.
+	pal := buf[fileHeaderSize+infoHeaderSize:]
+	for i, c := range p.Palette {
+		r, g, b, _ := c.RGBA()
+		pal[i*4+0] = byte(b >> 8)
+		pal[i*4+1] = byte(g >> 8)
+		pal[i*4+2] = byte(r >> 8)
+	}
+
+	// Pixel data, bottom-up as BMP requires.
+	pix := buf[pixelDataOffset:]
+	for y := 0; y < height; y++ {
+		srcRow := p.Pix[y*p.Stride : y*p.Stride+width]
+		dstRow := pix[(height-1-y)*rowSize:]
+		copy(dstRow, srcRow)
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
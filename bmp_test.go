@@ -0,0 +1,52 @@
+package posterize
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"testing"
+
+	"golang.org/x/image/bmp"
+)
+
+// TestEncodeBMPRoundTrip guards the BMP header math against drifting out
+// of sync with the palette/pixel data actually written: a mismatched
+// biClrUsed or pixelDataOffset produces a file golang.org/x/image/bmp
+// (the reference decoder cited in chunk0-1) rejects outright.
+func TestEncodeBMPRoundTrip(t *testing.T) {
+	for _, numColors := range []int{2, 4, 16, 256} {
+		palette := make(color.Palette, numColors)
+		for i := range palette {
+			palette[i] = color.RGBA{R: uint8(i), G: uint8(i * 2), B: uint8(i * 3), A: 255}
+		}
+
+		src := image.NewPaletted(image.Rect(0, 0, 5, 3), palette)
+		for i := range src.Pix {
+			src.Pix[i] = uint8(i % numColors)
+		}
+
+		var buf bytes.Buffer
+		if err := Encode(&buf, src, BMP); err != nil {
+			t.Fatalf("numColors=%d: Encode: %v", numColors, err)
+		}
+
+		decoded, err := bmp.Decode(&buf)
+		if err != nil {
+			t.Fatalf("numColors=%d: bmp.Decode: %v", numColors, err)
+		}
+		if decoded.Bounds() != src.Bounds() {
+			t.Fatalf("numColors=%d: bounds = %v, want %v", numColors, decoded.Bounds(), src.Bounds())
+		}
+
+		bounds := src.Bounds()
+		for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+			for x := bounds.Min.X; x < bounds.Max.X; x++ {
+				wantR, wantG, wantB, _ := src.At(x, y).RGBA()
+				gotR, gotG, gotB, _ := decoded.At(x, y).RGBA()
+				if gotR != wantR || gotG != wantG || gotB != wantB {
+					t.Fatalf("numColors=%d: pixel (%d,%d) = %v, want %v", numColors, x, y, decoded.At(x, y), src.At(x, y))
+				}
+			}
+		}
+	}
+}
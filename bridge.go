@@ -0,0 +1,49 @@
+package posterize
+
+/*
+#cgo CXXFLAGS: -std=c++17
+#include "posterize.h"
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"image"
+	"image/color"
+	"unsafe"
+)
+
+// posterizeRGBA runs the C++ quantizer over rgba's pixels and returns one
+// palette index per pixel (row-major, unpacked to a byte each so callers
+// can drop the result straight into image.Paletted.Pix) plus the
+// extracted palette, sized bitDepth.levels() colors.
+func posterizeRGBA(rgba *image.RGBA, bitDepth BitDepth) ([]uint8, color.Palette, error) {
+	bounds := rgba.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	numPixels := width * height
+	numColors := bitDepth.levels()
+
+	packed := make([]uint8, (numPixels*int(bitDepth)+7)/8)
+	paletteRGB := make([]uint8, numColors*3)
+
+	C.posterize(
+		(*C.uchar)(&packed[0]),
+		(*C.uchar)(&paletteRGB[0]),
+		(*C.uchar)(unsafe.Pointer(&rgba.Pix[0])),
+		C.size_t(numPixels),
+		C.int(bitDepth),
+	)
+
+	indices := Unpack(packed, int(bitDepth), numPixels)
+
+	palette := make(color.Palette, numColors)
+	for i := 0; i < numColors; i++ {
+		palette[i] = color.RGBA{
+			R: paletteRGB[i*3+0],
+			G: paletteRGB[i*3+1],
+			B: paletteRGB[i*3+2],
+			A: 0xff,
+		}
+	}
+	return indices, palette, nil
+}
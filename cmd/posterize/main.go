@@ -0,0 +1,66 @@
+// Command posterize reduces an image down to a 16-color palette and
+// writes the result back out in a lossless, palette-preserving format.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"image/jpeg"
+	"os"
+
+	"github.com/trzy/posterize-go"
+)
+
+func main() {
+	inPath := flag.String("in", "bouquet.jpg", "input JPEG path")
+	format := flag.String("format", "png", "output format: jpg, png, gif, or bmp")
+	dither := flag.String("dither", "none", "dithering: none, floyd-steinberg, bayer4x4, or bayer8x8")
+	outPath := flag.String("out", "", "output path (defaults to bouquet_4bit.<format>)")
+	flag.Parse()
+
+	outFormat, err := posterize.ParseFormat(*format)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	outDither, err := posterize.ParseDither(*dither)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	if *outPath == "" {
+		*outPath = "bouquet_4bit." + *format
+	}
+
+	in, err := os.Open(*inPath)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	defer in.Close()
+
+	img, err := jpeg.Decode(in)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	posterized, err := posterize.Posterize(img, &posterize.Options{Dither: outDither})
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+
+	out, err := os.Create(*outPath)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	defer out.Close()
+
+	if err := posterize.Encode(out, posterized, outFormat); err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(1)
+	}
+	fmt.Println("Success")
+}
@@ -0,0 +1,203 @@
+package posterize
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+)
+
+// Dither selects how source pixels are re-mapped onto the extracted
+// palette after quantization. Pure nearest-color mapping (DitherNone)
+// produces visible banding; the others trade it for noise or a
+// repeating pattern.
+type Dither int
+
+const (
+	// DitherNone assigns each pixel to its single nearest palette color.
+	DitherNone Dither = iota
+	// DitherFloydSteinberg diffuses each pixel's quantization error into
+	// its right/below neighbors using the classic Floyd–Steinberg kernel.
+	DitherFloydSteinberg
+	// DitherBayer4x4 adds a 4x4 ordered threshold pattern before
+	// nearest-color lookup. No error buffer, so it's trivially
+	// parallelizable per-row, at the cost of a visible repeating
+	// pattern.
+	DitherBayer4x4
+	// DitherBayer8x8 is DitherBayer4x4 with a finer, less visible
+	// pattern.
+	DitherBayer8x8
+)
+
+func (d Dither) valid() bool {
+	switch d {
+	case DitherNone, DitherFloydSteinberg, DitherBayer4x4, DitherBayer8x8:
+		return true
+	default:
+		return false
+	}
+}
+
+var bayer4x4 = normalizeBayer([][]int{
+	{0, 8, 2, 10},
+	{12, 4, 14, 6},
+	{3, 11, 1, 9},
+	{15, 7, 13, 5},
+})
+
+var bayer8x8 = normalizeBayer([][]int{
+	{0, 48, 12, 60, 3, 51, 15, 63},
+	{32, 16, 44, 28, 35, 19, 47, 31},
+	{8, 56, 4, 52, 11, 59, 7, 55},
+	{40, 24, 36, 20, 43, 27, 39, 23},
+	{2, 50, 14, 62, 1, 49, 13, 61},
+	{34, 18, 46, 30, 33, 17, 45, 29},
+	{10, 58, 6, 54, 9, 57, 5, 53},
+	{42, 26, 38, 22, 41, 25, 37, 21},
+})
+
+// normalizeBayer scales an integer Bayer matrix to [-0.5, 0.5), in units
+// of one palette step, so callers can just multiply by the step size.
+func normalizeBayer(m [][]int) [][]float64 {
+	n := len(m)
+	out := make([][]float64, n)
+	for y, row := range m {
+		out[y] = make([]float64, n)
+		for x, v := range row {
+			out[y][x] = (float64(v)+0.5)/float64(n*n) - 0.5
+		}
+	}
+	return out
+}
+
+// ParseDither maps a CLI-style dither name (none, floyd-steinberg,
+// bayer4x4, bayer8x8) to a Dither, for callers building a -dither flag
+// on top of this package.
+func ParseDither(name string) (Dither, error) {
+	switch name {
+	case "none":
+		return DitherNone, nil
+	case "floyd-steinberg":
+		return DitherFloydSteinberg, nil
+	case "bayer4x4":
+		return DitherBayer4x4, nil
+	case "bayer8x8":
+		return DitherBayer8x8, nil
+	default:
+		return 0, fmt.Errorf("posterize: unknown dither %q", name)
+	}
+}
+
+func clampByte(v float64) uint8 {
+	if v < 0 {
+		return 0
+	}
+	if v > 255 {
+		return 255
+	}
+	return uint8(v)
+}
+
+// ditherIndices re-maps every pixel in rgba onto palette using d,
+// returning one palette index per pixel in row-major order.
+func ditherIndices(rgba *image.RGBA, palette color.Palette, d Dither) []uint8 {
+	switch d {
+	case DitherFloydSteinberg:
+		return ditherFloydSteinberg(rgba, palette)
+	case DitherBayer4x4:
+		return ditherOrdered(rgba, palette, bayer4x4)
+	case DitherBayer8x8:
+		return ditherOrdered(rgba, palette, bayer8x8)
+	default:
+		return nearestIndices(rgba, palette)
+	}
+}
+
+func nearestIndices(rgba *image.RGBA, palette color.Palette) []uint8 {
+	bounds := rgba.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	indices := make([]uint8, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := rgba.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			indices[y*width+x] = uint8(palette.Index(c))
+		}
+	}
+	return indices
+}
+
+// ditherFloydSteinberg walks rgba left-to-right/top-to-bottom in a
+// float64 RGB buffer so diffused error isn't clipped and re-accumulated
+// inside a narrower uint8 range. At each pixel it picks the nearest
+// palette color, then spreads the quantization error 7/16 E, 3/16 SW,
+// 5/16 S, 1/16 SE (clamped/skipped at image borders).
+func ditherFloydSteinberg(rgba *image.RGBA, palette color.Palette) []uint8 {
+	bounds := rgba.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	type pixel struct{ r, g, b float64 }
+	buf := make([]pixel, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := rgba.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			buf[y*width+x] = pixel{float64(c.R), float64(c.G), float64(c.B)}
+		}
+	}
+
+	spread := func(x, y int, errR, errG, errB, frac float64) {
+		if x < 0 || x >= width || y < 0 || y >= height {
+			return
+		}
+		p := &buf[y*width+x]
+		p.r += errR * frac
+		p.g += errG * frac
+		p.b += errB * frac
+	}
+
+	indices := make([]uint8, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := buf[y*width+x]
+			old := color.RGBA{clampByte(p.r), clampByte(p.g), clampByte(p.b), 255}
+			idx := palette.Index(old)
+			indices[y*width+x] = uint8(idx)
+
+			chosen := palette[idx].(color.RGBA)
+			errR := p.r - float64(chosen.R)
+			errG := p.g - float64(chosen.G)
+			errB := p.b - float64(chosen.B)
+
+			spread(x+1, y, errR, errG, errB, 7.0/16)
+			spread(x-1, y+1, errR, errG, errB, 3.0/16)
+			spread(x, y+1, errR, errG, errB, 5.0/16)
+			spread(x+1, y+1, errR, errG, errB, 1.0/16)
+		}
+	}
+	return indices
+}
+
+// ditherOrdered adds matrix[y%n][x%n]*step to each channel before
+// nearest-color lookup, where n is matrix's side length and step is one
+// palette quantization step. It needs no error buffer, so each pixel is
+// independent.
+func ditherOrdered(rgba *image.RGBA, palette color.Palette, matrix [][]float64) []uint8 {
+	bounds := rgba.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	n := len(matrix)
+	step := 256.0 / float64(len(palette))
+
+	indices := make([]uint8, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			threshold := matrix[y%n][x%n] * step
+			c := rgba.RGBAAt(bounds.Min.X+x, bounds.Min.Y+y)
+			adjusted := color.RGBA{
+				R: clampByte(float64(c.R) + threshold),
+				G: clampByte(float64(c.G) + threshold),
+				B: clampByte(float64(c.B) + threshold),
+				A: 255,
+			}
+			indices[y*width+x] = uint8(palette.Index(adjusted))
+		}
+	}
+	return indices
+}
@@ -0,0 +1,69 @@
+package posterize
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+var testPalette = color.Palette{
+	color.RGBA{R: 0, G: 0, B: 0, A: 255},
+	color.RGBA{R: 255, G: 255, B: 255, A: 255},
+}
+
+// TestDitherIndicesFlatImage exercises every Dither mode against a flat
+// image exactly matching one palette entry: every pixel should land on
+// that entry, whether or not error/threshold adjustment is in play.
+func TestDitherIndicesFlatImage(t *testing.T) {
+	rgba := image.NewRGBA(image.Rect(0, 0, 9, 9))
+	for i := 0; i < len(rgba.Pix); i += 4 {
+		rgba.Pix[i+0] = 255
+		rgba.Pix[i+1] = 255
+		rgba.Pix[i+2] = 255
+		rgba.Pix[i+3] = 255
+	}
+
+	for _, d := range []Dither{DitherNone, DitherFloydSteinberg, DitherBayer4x4, DitherBayer8x8} {
+		indices := ditherIndices(rgba, testPalette, d)
+		if len(indices) != 9*9 {
+			t.Fatalf("dither %d: len(indices) = %d, want %d", d, len(indices), 9*9)
+		}
+		for i, idx := range indices {
+			if idx != 1 {
+				t.Fatalf("dither %d: indices[%d] = %d, want 1 (white)", d, i, idx)
+			}
+		}
+	}
+}
+
+// TestDitherIndicesInRange guards against an out-of-range palette index
+// escaping any dither path on a noisier image.
+func TestDitherIndicesInRange(t *testing.T) {
+	rgba := testImage(16, 16)
+
+	for _, d := range []Dither{DitherNone, DitherFloydSteinberg, DitherBayer4x4, DitherBayer8x8} {
+		indices := ditherIndices(rgba, testPalette, d)
+		for i, idx := range indices {
+			if int(idx) >= len(testPalette) {
+				t.Fatalf("dither %d: indices[%d] = %d, out of range for %d-color palette", d, i, idx, len(testPalette))
+			}
+		}
+	}
+}
+
+// TestPosterizeRejectsInvalidDither guards against an unknown Dither
+// value being accepted instead of rejected, the way Posterize already
+// rejects an invalid BitDepth.
+func TestPosterizeRejectsInvalidDither(t *testing.T) {
+	img := testImage(4, 4)
+	if _, err := Posterize(img, &Options{Dither: Dither(99)}); err == nil {
+		t.Fatal("Posterize(invalid dither) = nil error, want error")
+	}
+}
+
+func TestPosterizeGIFRejectsInvalidDither(t *testing.T) {
+	g := testGIF()
+	if _, err := PosterizeGIF(g, &Options{Dither: Dither(99)}); err == nil {
+		t.Fatal("PosterizeGIF(invalid dither) = nil error, want error")
+	}
+}
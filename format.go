@@ -0,0 +1,59 @@
+package posterize
+
+import (
+	"fmt"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Format identifies an output image format supported by Encode.
+type Format int
+
+const (
+	JPEG Format = iota
+	PNG
+	GIF
+	BMP
+)
+
+// Encode writes the posterized image p to w in the given format. PNG and
+// GIF both store p's palette natively, so the 16-color reduction this
+// package performs actually survives to disk; JPEG re-compresses to
+// continuous tone, so its output is no longer limited to the palette's
+// colors, and is offered mainly for comparison with the pre-palette
+// output. BMP
+// stores the palette uncompressed, for tools that expect one.
+func Encode(w io.Writer, p *image.Paletted, format Format) error {
+	switch format {
+	case JPEG:
+		return jpeg.Encode(w, p, nil)
+	case PNG:
+		return png.Encode(w, p)
+	case GIF:
+		return gif.Encode(w, p, nil)
+	case BMP:
+		return encodeBMP(w, p)
+	default:
+		return fmt.Errorf("posterize: unknown format %d", format)
+	}
+}
+
+// ParseFormat maps a CLI-style format name (jpg, png, gif, bmp) to a
+// Format, for callers building a -format flag on top of this package.
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "jpg", "jpeg":
+		return JPEG, nil
+	case "png":
+		return PNG, nil
+	case "gif":
+		return GIF, nil
+	case "bmp":
+		return BMP, nil
+	default:
+		return 0, fmt.Errorf("posterize: unknown format %q", name)
+	}
+}
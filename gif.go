@@ -0,0 +1,113 @@
+package posterize
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+)
+
+// PosterizeGIF posterizes every frame of g against one shared palette so
+// the 16 (or Options.BitDepth-sized) colors stay temporally stable
+// instead of flickering between frames the way quantizing each frame
+// independently would.
+func PosterizeGIF(g *gif.GIF, opts *Options) (*gif.GIF, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	bitDepth := opts.bitDepth()
+	if !bitDepth.valid() {
+		return nil, fmt.Errorf("posterize: invalid bit depth %d", bitDepth)
+	}
+	if !opts.Dither.valid() {
+		return nil, fmt.Errorf("posterize: invalid dither %d", opts.Dither)
+	}
+	if len(g.Image) == 0 {
+		return nil, fmt.Errorf("posterize: gif has no frames")
+	}
+	if g.Config.Width == 0 || g.Config.Height == 0 {
+		return nil, fmt.Errorf("posterize: gif has zero dimensions")
+	}
+
+	canvasBounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	frames := compositeFrames(g, canvasBounds)
+
+	// Concatenate every frame's pixels vertically so the quantizer sees
+	// the whole animation at once and picks one palette for all of it.
+	combined := image.NewRGBA(image.Rect(0, 0, canvasBounds.Dx(), canvasBounds.Dy()*len(frames)))
+	for i, frame := range frames {
+		dst := image.Rect(0, i*canvasBounds.Dy(), canvasBounds.Dx(), (i+1)*canvasBounds.Dy())
+		draw.Draw(combined, dst, frame, canvasBounds.Min, draw.Src)
+	}
+
+	_, palette, err := posterizeRGBA(combined, bitDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &gif.GIF{
+		Image:     make([]*image.Paletted, len(frames)),
+		Delay:     append([]int(nil), g.Delay...),
+		Disposal:  append([]byte(nil), g.Disposal...),
+		LoopCount: g.LoopCount,
+		Config:    g.Config,
+		// BackgroundIndex isn't carried over: it indexed g's original
+		// palette, which this function replaces with a new, shared one
+		// where that index would point at an unrelated color.
+	}
+	for i, frame := range frames {
+		rect := g.Image[i].Rect
+		sub := subRGBA(frame, rect)
+
+		var indices []uint8
+		if opts.Dither != DitherNone {
+			indices = ditherIndices(sub, palette, opts.Dither)
+		} else {
+			indices = nearestIndices(sub, palette)
+		}
+
+		paletted := image.NewPaletted(rect, palette)
+		copy(paletted.Pix, indices)
+		out.Image[i] = paletted
+	}
+	return out, nil
+}
+
+// compositeFrames renders every frame of g onto a shared canvas,
+// honoring each frame's disposal method, and returns one fully-composed
+// *image.RGBA per frame.
+func compositeFrames(g *gif.GIF, bounds image.Rectangle) []*image.RGBA {
+	canvas := image.NewRGBA(bounds)
+	frames := make([]*image.RGBA, len(g.Image))
+	var previous *image.RGBA
+
+	for i, frame := range g.Image {
+		if g.Disposal[i] == gif.DisposalPrevious {
+			previous = cloneRGBA(canvas)
+		}
+
+		draw.Draw(canvas, frame.Rect, frame, frame.Rect.Min, draw.Over)
+		frames[i] = cloneRGBA(canvas)
+
+		switch g.Disposal[i] {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, frame.Rect, image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return frames
+}
+
+func cloneRGBA(src *image.RGBA) *image.RGBA {
+	dst := image.NewRGBA(src.Bounds())
+	copy(dst.Pix, src.Pix)
+	return dst
+}
+
+// subRGBA extracts the r sub-rectangle of img as its own *image.RGBA.
+func subRGBA(img *image.RGBA, r image.Rectangle) *image.RGBA {
+	out := image.NewRGBA(r)
+	draw.Draw(out, r, img, r.Min, draw.Src)
+	return out
+}
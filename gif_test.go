@@ -0,0 +1,88 @@
+package posterize
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"reflect"
+	"testing"
+)
+
+func solidFrame(rect image.Rectangle, c color.Color) *image.Paletted {
+	palette := color.Palette{c}
+	frame := image.NewPaletted(rect, palette)
+	for i := range frame.Pix {
+		frame.Pix[i] = 0
+	}
+	return frame
+}
+
+func testGIF() *gif.GIF {
+	full := image.Rect(0, 0, 8, 8)
+	sub := image.Rect(2, 2, 6, 6)
+	return &gif.GIF{
+		Image: []*image.Paletted{
+			solidFrame(full, color.RGBA{R: 255, A: 255}),
+			solidFrame(sub, color.RGBA{B: 255, A: 255}),
+			solidFrame(full, color.RGBA{G: 255, A: 255}),
+		},
+		Delay:     []int{10, 20, 30},
+		Disposal:  []byte{gif.DisposalNone, gif.DisposalBackground, gif.DisposalPrevious},
+		LoopCount: 5,
+		Config:    image.Config{Width: 8, Height: 8},
+	}
+}
+
+// TestPosterizeGIFPreservesFrameMetadata checks that Delay, Disposal,
+// LoopCount, and each frame's Rect survive PosterizeGIF untouched, and
+// that every output frame shares one palette.
+func TestPosterizeGIFPreservesFrameMetadata(t *testing.T) {
+	src := testGIF()
+	out, err := PosterizeGIF(src, &Options{BitDepth: BitDepth1})
+	if err != nil {
+		t.Fatalf("PosterizeGIF: %v", err)
+	}
+
+	if !reflect.DeepEqual(out.Delay, src.Delay) {
+		t.Errorf("Delay = %v, want %v", out.Delay, src.Delay)
+	}
+	if !reflect.DeepEqual(out.Disposal, src.Disposal) {
+		t.Errorf("Disposal = %v, want %v", out.Disposal, src.Disposal)
+	}
+	if out.LoopCount != src.LoopCount {
+		t.Errorf("LoopCount = %d, want %d", out.LoopCount, src.LoopCount)
+	}
+	if len(out.Image) != len(src.Image) {
+		t.Fatalf("len(Image) = %d, want %d", len(out.Image), len(src.Image))
+	}
+	for i, frame := range out.Image {
+		if frame.Rect != src.Image[i].Rect {
+			t.Errorf("frame %d: Rect = %v, want %v", i, frame.Rect, src.Image[i].Rect)
+		}
+		if &frame.Palette[0] != &out.Image[0].Palette[0] {
+			t.Errorf("frame %d: does not share the first frame's palette", i)
+		}
+	}
+}
+
+func TestPosterizeGIFRejectsEmpty(t *testing.T) {
+	if _, err := PosterizeGIF(&gif.GIF{}, nil); err == nil {
+		t.Fatal("PosterizeGIF(empty gif) = nil error, want error")
+	}
+}
+
+// TestPosterizeGIFRejectsZeroDimensions guards against a *gif.GIF built
+// by hand (rather than via gif.Decode) with frames but a zero-sized
+// Config, which would otherwise reach posterizeRGBA with a 0-pixel
+// image and panic instead of returning an error.
+func TestPosterizeGIFRejectsZeroDimensions(t *testing.T) {
+	g := &gif.GIF{
+		Image:    []*image.Paletted{image.NewPaletted(image.Rect(0, 0, 0, 0), nil)},
+		Delay:    []int{0},
+		Disposal: []byte{gif.DisposalNone},
+		Config:   image.Config{Width: 0, Height: 0},
+	}
+	if _, err := PosterizeGIF(g, nil); err == nil {
+		t.Fatal("PosterizeGIF(zero-dimension gif) = nil error, want error")
+	}
+}
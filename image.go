@@ -0,0 +1,29 @@
+package posterize
+
+import (
+	"image"
+	"image/draw"
+)
+
+// toRGBA returns img as an *image.RGBA with no stride padding, reusing
+// img's own Pix when it's already in that layout. In that case the
+// result aliases img's backing array, so callers must not mutate img
+// while it's still in use. Everything else goes through draw.Draw into
+// a fresh copy.
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	width := bounds.Dx()
+
+	switch src := img.(type) {
+	case *image.RGBA:
+		if src.Stride == width*4 && bounds.Min == (image.Point{}) {
+			return src
+		}
+	case *image.NRGBA, *image.YCbCr:
+		// Handled by the draw.Draw fallback below.
+	}
+
+	rgba := image.NewRGBA(bounds)
+	draw.Draw(rgba, bounds, img, bounds.Min, draw.Src)
+	return rgba
+}
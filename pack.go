@@ -0,0 +1,57 @@
+package posterize
+
+// BitDepth selects how many bits each output pixel packs into, and thus
+// how many palette colors Posterize extracts: 1 bpp -> 2 colors, 2 bpp
+// -> 4, 4 bpp -> 16 (the default posterization), 8 bpp -> 256.
+type BitDepth int
+
+const (
+	BitDepth1 BitDepth = 1
+	BitDepth2 BitDepth = 2
+	BitDepth4 BitDepth = 4
+	BitDepth8 BitDepth = 8
+)
+
+// levels returns the number of palette colors this bit depth allows.
+func (d BitDepth) levels() int {
+	return 1 << uint(d)
+}
+
+func (d BitDepth) valid() bool {
+	switch d {
+	case BitDepth1, BitDepth2, BitDepth4, BitDepth8:
+		return true
+	default:
+		return false
+	}
+}
+
+// Pack packs indices (one per pixel, each < 1<<bitsPerPixel) into bytes,
+// bitsPerPixel bits per pixel, most-significant bits first within each
+// byte — the layout the C posterize routine expects.
+func Pack(indices []uint8, bitsPerPixel int) []uint8 {
+	pixelsPerByte := 8 / bitsPerPixel
+	packed := make([]uint8, (len(indices)+pixelsPerByte-1)/pixelsPerByte)
+	for i, idx := range indices {
+		byteIdx := i / pixelsPerByte
+		slot := i % pixelsPerByte
+		shift := 8 - bitsPerPixel*(slot+1)
+		packed[byteIdx] |= idx << uint(shift)
+	}
+	return packed
+}
+
+// Unpack reverses Pack, expanding numPixels bitsPerPixel-wide indices
+// packed into bytes back out to one index per byte.
+func Unpack(packed []uint8, bitsPerPixel, numPixels int) []uint8 {
+	pixelsPerByte := 8 / bitsPerPixel
+	mask := uint8(1<<uint(bitsPerPixel)) - 1
+	out := make([]uint8, numPixels)
+	for i := range out {
+		byteIdx := i / pixelsPerByte
+		slot := i % pixelsPerByte
+		shift := 8 - bitsPerPixel*(slot+1)
+		out[i] = (packed[byteIdx] >> uint(shift)) & mask
+	}
+	return out
+}
@@ -0,0 +1,35 @@
+package posterize
+
+import "testing"
+
+// TestPackUnpackRoundTrip guards the bit-packing math shared by the C
+// ABI and the Pack/Unpack helpers: packing then unpacking a set of
+// indices at every supported bit depth must return the originals.
+func TestPackUnpackRoundTrip(t *testing.T) {
+	for _, bitsPerPixel := range []int{1, 2, 4, 8} {
+		levels := 1 << uint(bitsPerPixel)
+		numPixels := 37 // deliberately not a multiple of any pixelsPerByte above 1
+		indices := make([]uint8, numPixels)
+		for i := range indices {
+			indices[i] = uint8(i % levels)
+		}
+
+		packed := Pack(indices, bitsPerPixel)
+		got := Unpack(packed, bitsPerPixel, numPixels)
+
+		for i, want := range indices {
+			if got[i] != want {
+				t.Fatalf("bitsPerPixel=%d: index %d = %d, want %d", bitsPerPixel, i, got[i], want)
+			}
+		}
+	}
+}
+
+func TestPackBitLayout(t *testing.T) {
+	// Two 4-bit indices pack into one byte, high nibble first.
+	got := Pack([]uint8{0xA, 0x3}, 4)
+	want := []uint8{0xA3}
+	if len(got) != 1 || got[0] != want[0] {
+		t.Fatalf("Pack = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,63 @@
+// Package posterize reduces a full-color image down to a small, fixed
+// palette. Color selection is delegated to a small C++ routine (see
+// bridge.go); this file exposes the Go-facing API on top of it.
+package posterize
+
+import (
+	"fmt"
+	"image"
+)
+
+// Options configures a call to Posterize.
+type Options struct {
+	// BitDepth selects how many colors the output palette has (1<<d).
+	// The zero value defaults to BitDepth4 (16 colors).
+	BitDepth BitDepth
+
+	// Dither selects how source pixels are re-mapped onto the palette.
+	// The zero value is DitherNone (plain nearest-color mapping).
+	Dither Dither
+}
+
+func (o *Options) bitDepth() BitDepth {
+	if o.BitDepth == 0 {
+		return BitDepth4
+	}
+	return o.BitDepth
+}
+
+// Posterize quantizes img down to 1<<Options.BitDepth colors (16 by
+// default) and returns the result as an *image.Paletted, whose Palette
+// holds the extracted color.RGBA entries and whose Pix holds the
+// per-pixel palette index.
+func Posterize(img image.Image, opts *Options) (*image.Paletted, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	bitDepth := opts.bitDepth()
+	if !bitDepth.valid() {
+		return nil, fmt.Errorf("posterize: invalid bit depth %d", bitDepth)
+	}
+	if !opts.Dither.valid() {
+		return nil, fmt.Errorf("posterize: invalid dither %d", opts.Dither)
+	}
+
+	rgba := toRGBA(img)
+	bounds := rgba.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("posterize: image has zero dimensions")
+	}
+
+	indices, palette, err := posterizeRGBA(rgba, bitDepth)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Dither != DitherNone {
+		indices = ditherIndices(rgba, palette, opts.Dither)
+	}
+
+	out := image.NewPaletted(image.Rect(0, 0, width, height), palette)
+	copy(out.Pix, indices)
+	return out, nil
+}
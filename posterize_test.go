@@ -0,0 +1,84 @@
+package posterize
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+// slowToRGBA mirrors the original decodeJPEGToLinearRGBA/
+// linearRGBAToImage pixel-by-pixel conversion via At()/RGBA(), kept here
+// only so BenchmarkPosterize can show what toRGBA's fast path avoids.
+func slowToRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	out := image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r, g, b, a := img.At(x, y).RGBA()
+			out.SetRGBA(x, y, color.RGBA{
+				R: uint8(r >> 8),
+				G: uint8(g >> 8),
+				B: uint8(b >> 8),
+				A: uint8(a >> 8),
+			})
+		}
+	}
+	return out
+}
+
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetRGBA(x, y, color.RGBA{
+				R: uint8(x),
+				G: uint8(y),
+				B: uint8(x + y),
+				A: 255,
+			})
+		}
+	}
+	return img
+}
+
+// TestToRGBAFastPathMatchesSlow guards the stride/origin check in
+// toRGBA's fast path: whichever branch it takes, the resulting pixels
+// must match the original per-pixel conversion.
+func TestToRGBAFastPathMatchesSlow(t *testing.T) {
+	img := testImage(37, 29) // dimensions deliberately not a multiple of 4
+
+	got := toRGBA(img)
+	want := slowToRGBA(img)
+
+	if got.Bounds() != want.Bounds() {
+		t.Fatalf("bounds = %v, want %v", got.Bounds(), want.Bounds())
+	}
+	bounds := got.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			if got.RGBAAt(x, y) != want.RGBAAt(x, y) {
+				t.Fatalf("pixel (%d,%d) = %v, want %v", x, y, got.RGBAAt(x, y), want.RGBAAt(x, y))
+			}
+		}
+	}
+}
+
+// BenchmarkPosterize compares the fast path (toRGBA reusing an existing
+// *image.RGBA's Pix directly) against the original per-pixel
+// At()/RGBA() conversion it replaced. Run with -bench=. to see the
+// difference on this machine.
+func BenchmarkPosterize(b *testing.B) {
+	img := testImage(1920, 1080)
+
+	b.Run("FastPath", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			toRGBA(img)
+		}
+	})
+
+	b.Run("SlowPath", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			slowToRGBA(img)
+		}
+	})
+}